@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile holds the per-endpoint defaults a user can select with --profile
+// instead of repeating flags or juggling environment variables.
+type Profile struct {
+	APIURL      string   `yaml:"api_url"`
+	Model       string   `yaml:"model"`
+	APIToken    string   `yaml:"api_token"`
+	Temperature *float64 `yaml:"temperature"`
+	TopP        *float64 `yaml:"top_p"`
+	MaxTokens   *int     `yaml:"max_tokens"`
+	System      string   `yaml:"system"`
+}
+
+// Config is the on-disk shape of ~/.config/vllmctl/config.yaml: a set of
+// named profiles, selected with --profile or VLLMCTL_PROFILE.
+type Config struct {
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// defaultConfigPath returns ~/.config/vllmctl/config.yaml.
+func defaultConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolving home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "vllmctl", "config.yaml"), nil
+}
+
+// loadConfig reads the config file at path. A missing file is not an error;
+// it just yields an empty Config so profile lookups fail gracefully.
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config file %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// profile looks up the named profile, returning an error if it isn't defined.
+func (c *Config) profile(name string) (Profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile %q not found in config", name)
+	}
+	return p, nil
+}
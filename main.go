@@ -1,61 +1,32 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"flag"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"strings"
+
+	"github.com/bplaxco/vllmctl/pkg/providers"
+	"github.com/bplaxco/vllmctl/pkg/tools"
+	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
 // Default Configuration values (used if environment variables are not set)
 const (
 	defaultVLLMAPIURL = "http://localhost:8000"
 	defaultVLLMModel  = "ibm-granite/granite-3.2-8b-instruct"
+	defaultProvider   = "vllm"
 )
 
-// Structures for the API request and response (OpenAI-compatible)
-type APIRequest struct {
-	Model       string    `json:"model"`
-	Messages    []Message `json:"messages"`
-	Stream      bool      `json:"stream,omitempty"`
-	Temperature float64   `json:"temperature,omitempty"` // Added Temperature field
-}
-
-type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
-
-type APIResponse struct {
-	ID      string   `json:"id"`
-	Object  string   `json:"object"`
-	Created int64    `json:"created"`
-	Model   string   `json:"model"`
-	Choices []Choice `json:"choices"`
-	Usage   struct {
-		PromptTokens     int `json:"prompt_tokens"`
-		CompletionTokens int `json:"completion_tokens"`
-		TotalTokens      int `json:"total_tokens"`
-	} `json:"usage"`
-}
-
-type Choice struct {
-	Index        int     `json:"index"`
-	Message      Message `json:"message"`
-	Logprobs     *string `json:"logprobs"`
-	FinishReason string  `json:"finish_reason"`
-}
+type Message = providers.Message
 
 func main() {
-	// Get configuration from environment variables or use defaults
+	// Get configuration from environment variables or use defaults. The
+	// API URL default depends on which provider is selected, so it's
+	// resolved later once --provider/VLLMCTL_PROVIDER is known.
 	vllmAPIURL := os.Getenv("VLLM_API_URL")
-	if vllmAPIURL == "" {
-		vllmAPIURL = defaultVLLMAPIURL
-	}
 
 	vllmModel := os.Getenv("VLLM_MODEL")
 	if vllmModel == "" {
@@ -64,12 +35,122 @@ func main() {
 
 	vllmAPIToken := os.Getenv("VLLM_API_TOKEN") // No default for token, it's optional
 
+	providerName := os.Getenv("VLLMCTL_PROVIDER")
+	if providerName == "" {
+		providerName = defaultProvider
+	}
+
 	// --- Command-line flag parsing ---
 	systemPrompt := flag.String("system", "You are a helpful assistant.", "System prompt for the LLM")
 	userPromptFlag := flag.String("user", "", "User prompt for the LLM (overrides stdin)")
 	temperatureFlag := flag.Float64("temperature", 0.7, "Temperature for LLM generation (e.g., 0.2 for more deterministic, 1.0 for more random)") // Added temperature flag
+	streamFlag := flag.Bool("stream", false, "Stream the response via SSE and print tokens as they arrive")
+	apiURLFlag := flag.String("api-url", "", "API base URL (overrides profile/env)")
+	modelFlag := flag.String("model", "", "Model name to request (overrides profile/env)")
+	tokenFlag := flag.String("token", "", "API token/key for the backend (overrides profile/env)")
+	topPFlag := flag.Float64("top-p", 0, "Top-p (nucleus sampling) value (overrides profile)")
+	maxTokensFlag := flag.Int("max-tokens", 0, "Maximum tokens to generate (overrides profile)")
+	profileFlag := flag.String("profile", os.Getenv("VLLMCTL_PROFILE"), "Named profile from ~/.config/vllmctl/config.yaml to use for defaults")
+	providerFlag := flag.String("provider", "", "Backend provider to target: vllm, openai, anthropic, or ollama (overrides VLLMCTL_PROVIDER)")
+	sessionFlag := flag.String("session", "", "Name of a session to load history from and append this turn to")
+	newSessionFlag := flag.Bool("new-session", false, "Start --session fresh, discarding any existing history for that name")
+	listSessionsFlag := flag.Bool("list-sessions", false, "List saved session names and exit")
+	clearSessionFlag := flag.Bool("clear-session", false, "Delete the history for --session and exit")
+	toolsFlag := flag.String("tools", "", "Comma-separated local tools to enable for function calling (shell, read_file, http_get)")
+	jsonFlag := flag.Bool("json", false, "Ask the backend for a JSON object response (response_format: json_object)")
+	schemaFlag := flag.String("schema", "", "Path to a JSON schema file; the response is validated against it and printing fails if it doesn't match")
+	grammarFlag := flag.String("grammar", "", "Path to a GBNF grammar file for vLLM guided decoding")
 	flag.Parse()
 
+	if *listSessionsFlag {
+		names, err := listSessions()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing sessions: %v\n", err)
+			os.Exit(1)
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return
+	}
+
+	if *clearSessionFlag {
+		if *sessionFlag == "" {
+			fmt.Fprintln(os.Stderr, "Error: --clear-session requires --session <name>")
+			os.Exit(1)
+		}
+		if err := clearSession(*sessionFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error clearing session: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	explicitFlags := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { explicitFlags[f.Name] = true })
+
+	var activeProfile Profile
+	if *profileFlag != "" {
+		configPath, err := defaultConfigPath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		cfg, err := loadConfig(configPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		activeProfile, err = cfg.profile(*profileFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Resolve effective settings with precedence: flag > profile > env/default.
+	if explicitFlags["provider"] {
+		providerName = *providerFlag
+	}
+
+	if explicitFlags["api-url"] {
+		vllmAPIURL = *apiURLFlag
+	} else if activeProfile.APIURL != "" {
+		vllmAPIURL = activeProfile.APIURL
+	}
+
+	if vllmAPIURL == "" && (providerName == "vllm" || providerName == "openai") {
+		vllmAPIURL = defaultVLLMAPIURL
+	}
+
+	if explicitFlags["model"] {
+		vllmModel = *modelFlag
+	} else if activeProfile.Model != "" {
+		vllmModel = activeProfile.Model
+	}
+
+	if explicitFlags["token"] {
+		vllmAPIToken = *tokenFlag
+	} else if activeProfile.APIToken != "" {
+		vllmAPIToken = activeProfile.APIToken
+	}
+
+	if !explicitFlags["system"] && activeProfile.System != "" {
+		*systemPrompt = activeProfile.System
+	}
+
+	if !explicitFlags["temperature"] && activeProfile.Temperature != nil {
+		*temperatureFlag = *activeProfile.Temperature
+	}
+
+	if !explicitFlags["top-p"] && activeProfile.TopP != nil {
+		*topPFlag = *activeProfile.TopP
+	}
+
+	if !explicitFlags["max-tokens"] && activeProfile.MaxTokens != nil {
+		*maxTokensFlag = *activeProfile.MaxTokens
+	}
+
 	var userPrompt string
 
 	if *userPromptFlag != "" {
@@ -97,65 +178,139 @@ func main() {
 		os.Exit(1)
 	}
 
-	// --- API Request Logic ---
-	messages := []Message{
-		{Role: "system", Content: *systemPrompt},
-		{Role: "user", Content: userPrompt},
+	// --- Provider selection ---
+	provider, err := providers.New(providerName, providers.Config{
+		APIURL: vllmAPIURL,
+		APIKey: vllmAPIToken,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	apiRequest := APIRequest{
-		Model:       vllmModel,
-		Messages:    messages,
-		Temperature: *temperatureFlag,
+	// --- Message history ---
+	var messages []Message
+	if *sessionFlag != "" {
+		if !*newSessionFlag {
+			history, err := loadSession(*sessionFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading session: %v\n", err)
+				os.Exit(1)
+			}
+			messages = history
+		}
+		if len(messages) == 0 {
+			messages = append(messages, Message{Role: "system", Content: *systemPrompt})
+		}
+		messages = append(messages, Message{Role: "user", Content: userPrompt})
+	} else {
+		messages = []Message{
+			{Role: "system", Content: *systemPrompt},
+			{Role: "user", Content: userPrompt},
+		}
 	}
 
-	jsonData, err := json.Marshal(apiRequest)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error marshalling JSON: %v\n", err)
-		os.Exit(1)
+	var toolRegistry tools.Registry
+	if *toolsFlag != "" {
+		if *streamFlag {
+			fmt.Fprintln(os.Stderr, "Error: --tools is not supported together with --stream")
+			os.Exit(1)
+		}
+		var err error
+		toolRegistry, err = tools.Select(strings.Split(*toolsFlag, ","))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	// Construct the full API endpoint
-	fullAPIURL := vllmAPIURL
-	if !strings.HasSuffix(fullAPIURL, "/") {
-		fullAPIURL += "/"
+	var schema *jsonschema.Schema
+	var guidedJSON map[string]any
+	var responseFormat *providers.ResponseFormat
+	if *schemaFlag != "" {
+		if *streamFlag {
+			fmt.Fprintln(os.Stderr, "Error: --schema is not supported together with --stream")
+			os.Exit(1)
+		}
+		var err error
+		schema, guidedJSON, err = loadJSONSchema(*schemaFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		responseFormat = &providers.ResponseFormat{Type: "json_schema", JSONSchema: guidedJSON}
+	} else if *jsonFlag {
+		responseFormat = &providers.ResponseFormat{Type: "json_object"}
 	}
-	fullAPIURL += "v1/chat/completions"
 
-	req, err := http.NewRequest("POST", fullAPIURL, bytes.NewBuffer(jsonData))
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating request: %v\n", err)
-		os.Exit(1)
+	var guidedGrammar string
+	if *grammarFlag != "" {
+		data, err := os.ReadFile(*grammarFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading grammar file: %v\n", err)
+			os.Exit(1)
+		}
+		guidedGrammar = string(data)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	if vllmAPIToken != "" {
-		req.Header.Set("Authorization", "Bearer "+vllmAPIToken)
+	chatRequest := providers.ChatRequest{
+		Model:          vllmModel,
+		Messages:       messages,
+		Temperature:    *temperatureFlag,
+		TopP:           *topPFlag,
+		MaxTokens:      *maxTokensFlag,
+		Tools:          toolDefs(toolRegistry),
+		ResponseFormat: responseFormat,
+		GuidedJSON:     guidedJSON,
+		GuidedGrammar:  guidedGrammar,
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error making request to vLLM API: %v\n", err)
-		os.Exit(1)
+	ctx := context.Background()
+
+	var assistantReply string
+	if *streamFlag {
+		resp, err := provider.StreamChat(ctx, chatRequest, func(delta string) {
+			fmt.Print(delta)
+		})
+		fmt.Println()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading stream: %v\n", err)
+			os.Exit(1)
+		}
+		assistantReply = resp.Content
+	} else if toolRegistry != nil {
+		resp, finalMessages, err := runToolLoop(ctx, provider, chatRequest, toolRegistry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error making request: %v\n", err)
+			os.Exit(1)
+		}
+		messages = finalMessages
+		assistantReply = resp.Content
+	} else {
+		resp, err := provider.Chat(ctx, chatRequest)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error making request: %v\n", err)
+			os.Exit(1)
+		}
+		assistantReply = resp.Content
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		fmt.Fprintf(os.Stderr, "Error: API request failed with status %s: %s\n", resp.Status, string(bodyBytes))
-		os.Exit(1)
+	if schema != nil {
+		if err := validateAgainstSchema(schema, assistantReply); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	var apiResponse APIResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-		fmt.Fprintf(os.Stderr, "Error decoding API response: %v\n", err)
-		os.Exit(1)
+	if !*streamFlag {
+		fmt.Println(assistantReply)
 	}
 
-	if len(apiResponse.Choices) > 0 {
-		fmt.Println(apiResponse.Choices[0].Message.Content)
-	} else {
-		fmt.Fprintln(os.Stderr, "No choices returned in API response.")
+	if *sessionFlag != "" && assistantReply != "" {
+		messages = append(messages, Message{Role: "assistant", Content: assistantReply})
+		if err := saveSession(*sessionFlag, messages); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving session: %v\n", err)
+			os.Exit(1)
+		}
 	}
 }
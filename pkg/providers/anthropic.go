@@ -0,0 +1,202 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const defaultAnthropicAPIURL = "https://api.anthropic.com"
+
+const anthropicVersion = "2023-06-01"
+
+// AnthropicProvider targets the Anthropic Messages API.
+type AnthropicProvider struct {
+	apiURL string
+	apiKey string
+	client *http.Client
+}
+
+func NewAnthropicProvider(cfg Config) *AnthropicProvider {
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultAnthropicAPIURL
+	}
+	return &AnthropicProvider{
+		apiURL: apiURL,
+		apiKey: cfg.APIKey,
+		client: &http.Client{},
+	}
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	System      string             `json:"system,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+	Stream      bool               `json:"stream,omitempty"`
+	Temperature float64            `json:"temperature,omitempty"`
+	TopP        float64            `json:"top_p,omitempty"`
+	MaxTokens   int                `json:"max_tokens"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StopReason string `json:"stop_reason"`
+}
+
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+}
+
+// splitSystem pulls the (at most one, leading) system message out of the
+// turn history, since Anthropic takes it as a top-level field rather than
+// a message with role "system".
+func splitSystem(messages []Message) (string, []anthropicMessage) {
+	var system string
+	converted := make([]anthropicMessage, 0, len(messages))
+	for _, m := range messages {
+		if m.Role == "system" {
+			system = m.Content
+			continue
+		}
+		converted = append(converted, anthropicMessage{Role: m.Role, Content: m.Content})
+	}
+	return system, converted
+}
+
+// anthropicMaxTokens is required by the Anthropic API; default to a
+// reasonable value when the caller hasn't set one.
+func anthropicMaxTokens(req ChatRequest) int {
+	if req.MaxTokens > 0 {
+		return req.MaxTokens
+	}
+	return 1024
+}
+
+func (p *AnthropicProvider) buildRequest(ctx context.Context, req ChatRequest, stream bool) (*http.Request, error) {
+	system, messages := splitSystem(req.Messages)
+
+	body := anthropicRequest{
+		Model:       req.Model,
+		System:      system,
+		Messages:    messages,
+		Stream:      stream,
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+		MaxTokens:   anthropicMaxTokens(req),
+	}
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling request: %w", err)
+	}
+
+	url := strings.TrimSuffix(p.apiURL, "/") + "/v1/messages"
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("anthropic-version", anthropicVersion)
+	if p.apiKey != "" {
+		httpReq.Header.Set("x-api-key", p.apiKey)
+	}
+	return httpReq, nil
+}
+
+func (p *AnthropicProvider) Chat(ctx context.Context, req ChatRequest) (Response, error) {
+	httpReq, err := p.buildRequest(ctx, req, false)
+	if err != nil {
+		return Response{}, err
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return Response{}, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return Response{}, fmt.Errorf("API request failed with status %s: %s", resp.Status, string(bodyBytes))
+	}
+
+	var apiResponse anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return Response{}, fmt.Errorf("decoding response: %w", err)
+	}
+
+	var text strings.Builder
+	for _, block := range apiResponse.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+
+	return Response{Content: text.String(), FinishReason: apiResponse.StopReason}, nil
+}
+
+func (p *AnthropicProvider) StreamChat(ctx context.Context, req ChatRequest, onDelta StreamFunc) (Response, error) {
+	httpReq, err := p.buildRequest(ctx, req, true)
+	if err != nil {
+		return Response{}, err
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return Response{}, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return Response{}, fmt.Errorf("API request failed with status %s: %s", resp.Status, string(bodyBytes))
+	}
+
+	var reply strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		payload := strings.TrimPrefix(line, "data: ")
+
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(payload), &event); err != nil {
+			return Response{Content: reply.String()}, fmt.Errorf("parsing event %q: %w", payload, err)
+		}
+
+		if event.Type == "content_block_delta" && event.Delta.Type == "text_delta" {
+			reply.WriteString(event.Delta.Text)
+			onDelta(event.Delta.Text)
+		}
+		if event.Type == "message_stop" {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Response{Content: reply.String()}, err
+	}
+
+	return Response{Content: reply.String()}, nil
+}
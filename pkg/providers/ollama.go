@@ -0,0 +1,156 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const defaultOllamaAPIURL = "http://localhost:11434"
+
+// OllamaProvider targets Ollama's /api/chat endpoint.
+type OllamaProvider struct {
+	apiURL string
+	client *http.Client
+}
+
+func NewOllamaProvider(cfg Config) *OllamaProvider {
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultOllamaAPIURL
+	}
+	return &OllamaProvider{
+		apiURL: apiURL,
+		client: &http.Client{},
+	}
+}
+
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Options  ollamaOptions   `json:"options,omitempty"`
+}
+
+type ollamaOptions struct {
+	Temperature float64 `json:"temperature,omitempty"`
+	TopP        float64 `json:"top_p,omitempty"`
+	NumPredict  int     `json:"num_predict,omitempty"`
+}
+
+type ollamaChunk struct {
+	Message ollamaMessage `json:"message"`
+	Done    bool          `json:"done"`
+}
+
+// Ollama has no auth header concept of its own; cfg.APIKey is unused here
+// but kept for a uniform Config across providers.
+func (p *OllamaProvider) buildRequest(ctx context.Context, req ChatRequest, stream bool) (*http.Request, error) {
+	messages := make([]ollamaMessage, len(req.Messages))
+	for i, m := range req.Messages {
+		messages[i] = ollamaMessage{Role: m.Role, Content: m.Content}
+	}
+
+	body := ollamaRequest{
+		Model:    req.Model,
+		Messages: messages,
+		Stream:   stream,
+		Options: ollamaOptions{
+			Temperature: req.Temperature,
+			TopP:        req.TopP,
+			NumPredict:  req.MaxTokens,
+		},
+	}
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling request: %w", err)
+	}
+
+	url := strings.TrimSuffix(p.apiURL, "/") + "/api/chat"
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	return httpReq, nil
+}
+
+func (p *OllamaProvider) Chat(ctx context.Context, req ChatRequest) (Response, error) {
+	httpReq, err := p.buildRequest(ctx, req, false)
+	if err != nil {
+		return Response{}, err
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return Response{}, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return Response{}, fmt.Errorf("API request failed with status %s: %s", resp.Status, string(bodyBytes))
+	}
+
+	var chunk ollamaChunk
+	if err := json.NewDecoder(resp.Body).Decode(&chunk); err != nil {
+		return Response{}, fmt.Errorf("decoding response: %w", err)
+	}
+
+	return Response{Content: chunk.Message.Content}, nil
+}
+
+func (p *OllamaProvider) StreamChat(ctx context.Context, req ChatRequest, onDelta StreamFunc) (Response, error) {
+	httpReq, err := p.buildRequest(ctx, req, true)
+	if err != nil {
+		return Response{}, err
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return Response{}, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return Response{}, fmt.Errorf("API request failed with status %s: %s", resp.Status, string(bodyBytes))
+	}
+
+	// Ollama streams newline-delimited JSON objects, not SSE "data: " lines.
+	var reply strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		var chunk ollamaChunk
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return Response{Content: reply.String()}, fmt.Errorf("parsing chunk %q: %w", line, err)
+		}
+
+		reply.WriteString(chunk.Message.Content)
+		onDelta(chunk.Message.Content)
+		if chunk.Done {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Response{Content: reply.String()}, err
+	}
+
+	return Response{Content: reply.String()}, nil
+}
@@ -0,0 +1,99 @@
+// Package providers abstracts over the chat completion backends vllmctl
+// can talk to (vLLM/OpenAI-compatible, Anthropic, Ollama) behind a single
+// Provider interface, so the CLI can target any of them via --provider.
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Message is a single chat turn, independent of any backend's wire format.
+// It carries JSON tags so callers (e.g. session persistence) can serialize
+// it directly without a backend-specific translation.
+type Message struct {
+	Role       string     `json:"role"`
+	Content    string     `json:"content"`
+	ToolCalls  []ToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string     `json:"tool_call_id,omitempty"`
+	Name       string     `json:"name,omitempty"`
+}
+
+// ToolDef describes a local tool the model may call, in OpenAI's function
+// tool-calling shape.
+type ToolDef struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+}
+
+// ToolCall is a single invocation of a tool requested by the model.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments json.RawMessage
+}
+
+// ResponseFormat constrains the shape of the model's reply, in OpenAI's
+// response_format shape ({"type": "json_object"} or {"type": "json_schema",
+// "json_schema": {...}}).
+type ResponseFormat struct {
+	Type       string
+	JSONSchema map[string]any
+}
+
+// ChatRequest holds the backend-agnostic parameters for a chat completion.
+type ChatRequest struct {
+	Model          string
+	Messages       []Message
+	Temperature    float64
+	TopP           float64
+	MaxTokens      int
+	Tools          []ToolDef
+	ToolChoice     string
+	ResponseFormat *ResponseFormat
+	// GuidedJSON and GuidedGrammar are passed through to vLLM's
+	// constrained-decoding extensions (guided_json / guided_grammar);
+	// other backends ignore them.
+	GuidedJSON    map[string]any
+	GuidedGrammar string
+}
+
+// Response is a completed, non-streamed chat reply.
+type Response struct {
+	Content      string
+	FinishReason string
+	ToolCalls    []ToolCall
+}
+
+// StreamFunc is called once per token/delta as a streamed reply arrives.
+type StreamFunc func(delta string)
+
+// Provider is implemented by each backend vllmctl can target. Chat performs
+// a single request/response call; StreamChat invokes onDelta as content
+// arrives and returns the full accumulated reply once the stream ends.
+type Provider interface {
+	Chat(ctx context.Context, req ChatRequest) (Response, error)
+	StreamChat(ctx context.Context, req ChatRequest, onDelta StreamFunc) (Response, error)
+}
+
+// Config holds the connection details needed to construct any Provider.
+type Config struct {
+	APIURL string
+	APIKey string
+}
+
+// New constructs the Provider registered under name.
+func New(name string, cfg Config) (Provider, error) {
+	switch name {
+	case "vllm", "openai":
+		return NewVLLMProvider(cfg), nil
+	case "anthropic":
+		return NewAnthropicProvider(cfg), nil
+	case "ollama":
+		return NewOllamaProvider(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q", name)
+	}
+}
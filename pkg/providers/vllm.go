@@ -0,0 +1,276 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// VLLMProvider targets any OpenAI-compatible /v1/chat/completions endpoint,
+// which is what vLLM (and OpenAI itself) serve.
+type VLLMProvider struct {
+	apiURL string
+	apiKey string
+	client *http.Client
+}
+
+func NewVLLMProvider(cfg Config) *VLLMProvider {
+	return &VLLMProvider{
+		apiURL: cfg.APIURL,
+		apiKey: cfg.APIKey,
+		client: &http.Client{},
+	}
+}
+
+type vllmMessage struct {
+	Role       string         `json:"role"`
+	Content    string         `json:"content,omitempty"`
+	ToolCalls  []vllmToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string         `json:"tool_call_id,omitempty"`
+	Name       string         `json:"name,omitempty"`
+}
+
+type vllmTool struct {
+	Type     string       `json:"type"`
+	Function vllmToolFunc `json:"function"`
+}
+
+type vllmToolFunc struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type vllmToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type vllmResponseFormat struct {
+	Type       string              `json:"type"`
+	JSONSchema *vllmJSONSchemaSpec `json:"json_schema,omitempty"`
+}
+
+type vllmJSONSchemaSpec struct {
+	Name   string         `json:"name"`
+	Schema map[string]any `json:"schema"`
+}
+
+type vllmRequest struct {
+	Model          string              `json:"model"`
+	Messages       []vllmMessage       `json:"messages"`
+	Stream         bool                `json:"stream,omitempty"`
+	Temperature    float64             `json:"temperature,omitempty"`
+	TopP           float64             `json:"top_p,omitempty"`
+	MaxTokens      int                 `json:"max_tokens,omitempty"`
+	Tools          []vllmTool          `json:"tools,omitempty"`
+	ToolChoice     string              `json:"tool_choice,omitempty"`
+	ResponseFormat *vllmResponseFormat `json:"response_format,omitempty"`
+	GuidedJSON     map[string]any      `json:"guided_json,omitempty"`
+	GuidedGrammar  string              `json:"guided_grammar,omitempty"`
+}
+
+type vllmResponse struct {
+	Choices []struct {
+		Message      vllmMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// toResponseToolCalls converts the wire-format tool calls on a message into
+// the backend-agnostic ToolCall shape.
+func toResponseToolCalls(calls []vllmToolCall) []ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+	converted := make([]ToolCall, len(calls))
+	for i, c := range calls {
+		converted[i] = ToolCall{
+			ID:        c.ID,
+			Name:      c.Function.Name,
+			Arguments: json.RawMessage(c.Function.Arguments),
+		}
+	}
+	return converted
+}
+
+// toRequestToolCalls converts backend-agnostic tool calls back into the
+// wire format, for re-sending an assistant message that requested them.
+func toRequestToolCalls(calls []ToolCall) []vllmToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+	converted := make([]vllmToolCall, len(calls))
+	for i, c := range calls {
+		converted[i] = vllmToolCall{ID: c.ID, Type: "function"}
+		converted[i].Function.Name = c.Name
+		converted[i].Function.Arguments = string(c.Arguments)
+	}
+	return converted
+}
+
+type vllmStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+func (p *VLLMProvider) buildRequest(ctx context.Context, req ChatRequest, stream bool) (*http.Request, error) {
+	messages := make([]vllmMessage, len(req.Messages))
+	for i, m := range req.Messages {
+		messages[i] = vllmMessage{
+			Role:       m.Role,
+			Content:    m.Content,
+			ToolCalls:  toRequestToolCalls(m.ToolCalls),
+			ToolCallID: m.ToolCallID,
+			Name:       m.Name,
+		}
+	}
+
+	var tools []vllmTool
+	for _, t := range req.Tools {
+		tools = append(tools, vllmTool{
+			Type: "function",
+			Function: vllmToolFunc{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.Parameters,
+			},
+		})
+	}
+
+	var responseFormat *vllmResponseFormat
+	if req.ResponseFormat != nil {
+		responseFormat = &vllmResponseFormat{Type: req.ResponseFormat.Type}
+		if req.ResponseFormat.JSONSchema != nil {
+			responseFormat.JSONSchema = &vllmJSONSchemaSpec{
+				Name:   "response",
+				Schema: req.ResponseFormat.JSONSchema,
+			}
+		}
+	}
+
+	body := vllmRequest{
+		Model:          req.Model,
+		Messages:       messages,
+		Stream:         stream,
+		Temperature:    req.Temperature,
+		TopP:           req.TopP,
+		MaxTokens:      req.MaxTokens,
+		Tools:          tools,
+		ToolChoice:     req.ToolChoice,
+		ResponseFormat: responseFormat,
+		GuidedJSON:     req.GuidedJSON,
+		GuidedGrammar:  req.GuidedGrammar,
+	}
+
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling request: %w", err)
+	}
+
+	url := strings.TrimSuffix(p.apiURL, "/") + "/v1/chat/completions"
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	return httpReq, nil
+}
+
+func (p *VLLMProvider) Chat(ctx context.Context, req ChatRequest) (Response, error) {
+	httpReq, err := p.buildRequest(ctx, req, false)
+	if err != nil {
+		return Response{}, err
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return Response{}, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return Response{}, fmt.Errorf("API request failed with status %s: %s", resp.Status, string(bodyBytes))
+	}
+
+	var apiResponse vllmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return Response{}, fmt.Errorf("decoding response: %w", err)
+	}
+
+	if len(apiResponse.Choices) == 0 {
+		return Response{}, fmt.Errorf("no choices returned in API response")
+	}
+
+	return Response{
+		Content:      apiResponse.Choices[0].Message.Content,
+		FinishReason: apiResponse.Choices[0].FinishReason,
+		ToolCalls:    toResponseToolCalls(apiResponse.Choices[0].Message.ToolCalls),
+	}, nil
+}
+
+func (p *VLLMProvider) StreamChat(ctx context.Context, req ChatRequest, onDelta StreamFunc) (Response, error) {
+	httpReq, err := p.buildRequest(ctx, req, true)
+	if err != nil {
+		return Response{}, err
+	}
+
+	resp, err := p.client.Do(httpReq)
+	if err != nil {
+		return Response{}, fmt.Errorf("making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return Response{}, fmt.Errorf("API request failed with status %s: %s", resp.Status, string(bodyBytes))
+	}
+
+	var reply strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+
+		payload := strings.TrimPrefix(line, "data: ")
+		if payload == "[DONE]" {
+			break
+		}
+
+		var chunk vllmStreamChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			return Response{Content: reply.String()}, fmt.Errorf("parsing chunk %q: %w", payload, err)
+		}
+
+		if len(chunk.Choices) > 0 {
+			delta := chunk.Choices[0].Delta.Content
+			reply.WriteString(delta)
+			onDelta(delta)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Response{Content: reply.String()}, err
+	}
+
+	return Response{Content: reply.String()}, nil
+}
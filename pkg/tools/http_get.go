@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPGetTool fetches a URL and returns its response body.
+type HTTPGetTool struct{}
+
+func (t *HTTPGetTool) Name() string { return "http_get" }
+func (t *HTTPGetTool) Description() string {
+	return "Fetch a URL via HTTP GET and return the response body."
+}
+
+func (t *HTTPGetTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"url": map[string]any{
+				"type":        "string",
+				"description": "The URL to fetch.",
+			},
+		},
+		"required": []string{"url"},
+	}
+}
+
+func (t *HTTPGetTool) Execute(args json.RawMessage) (string, error) {
+	var params struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return "", fmt.Errorf("parsing http_get arguments: %w", err)
+	}
+
+	resp, err := http.Get(params.URL)
+	if err != nil {
+		return "", fmt.Errorf("fetching %s: %w", params.URL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading response from %s: %w", params.URL, err)
+	}
+	return string(body), nil
+}
@@ -0,0 +1,41 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ReadFileTool returns the contents of a local file.
+type ReadFileTool struct{}
+
+func (t *ReadFileTool) Name() string        { return "read_file" }
+func (t *ReadFileTool) Description() string { return "Read and return the contents of a local file." }
+
+func (t *ReadFileTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"path": map[string]any{
+				"type":        "string",
+				"description": "Path of the file to read.",
+			},
+		},
+		"required": []string{"path"},
+	}
+}
+
+func (t *ReadFileTool) Execute(args json.RawMessage) (string, error) {
+	var params struct {
+		Path string `json:"path"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return "", fmt.Errorf("parsing read_file arguments: %w", err)
+	}
+
+	data, err := os.ReadFile(params.Path)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", params.Path, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// ShellTool runs an arbitrary shell command via "sh -c" and returns its
+// combined stdout/stderr. It is the most powerful (and most dangerous)
+// built-in tool, which is why every tool call is gated on user confirmation.
+type ShellTool struct{}
+
+func (t *ShellTool) Name() string        { return "shell" }
+func (t *ShellTool) Description() string { return "Run a shell command and return its output." }
+
+func (t *ShellTool) Parameters() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"command": map[string]any{
+				"type":        "string",
+				"description": "The shell command to execute.",
+			},
+		},
+		"required": []string{"command"},
+	}
+}
+
+func (t *ShellTool) Execute(args json.RawMessage) (string, error) {
+	var params struct {
+		Command string `json:"command"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return "", fmt.Errorf("parsing shell arguments: %w", err)
+	}
+
+	out, err := exec.Command("sh", "-c", params.Command).CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("command failed: %w", err)
+	}
+	return string(out), nil
+}
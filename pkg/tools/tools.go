@@ -0,0 +1,49 @@
+// Package tools implements the small set of local tools vllmctl can expose
+// to a tool-calling model: shell commands, reading files, and fetching
+// URLs. Each is executed only after the user confirms it.
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Tool is a single local capability a model can invoke via OpenAI-style
+// tool calling.
+type Tool interface {
+	// Name is the identifier the model uses in a tool call and that users
+	// pass to --tools.
+	Name() string
+	// Description is sent to the model so it knows when to call the tool.
+	Description() string
+	// Parameters is the JSON Schema for the tool's arguments object.
+	Parameters() map[string]any
+	// Execute runs the tool against the given JSON-encoded arguments and
+	// returns the content to feed back to the model as a "tool" message.
+	Execute(args json.RawMessage) (string, error)
+}
+
+// Registry is the set of tools available by name.
+type Registry map[string]Tool
+
+// builtins holds every tool vllmctl ships, keyed by name.
+var builtins = Registry{
+	"shell":     &ShellTool{},
+	"read_file": &ReadFileTool{},
+	"http_get":  &HTTPGetTool{},
+}
+
+// Select returns the Registry containing only the named builtin tools. The
+// Registry is a map, so the order of the names is not kept. An unknown name
+// is an error so typos in --tools don't silently disable a tool.
+func Select(names []string) (Registry, error) {
+	selected := Registry{}
+	for _, name := range names {
+		tool, ok := builtins[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown tool %q", name)
+		}
+		selected[name] = tool
+	}
+	return selected, nil
+}
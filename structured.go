@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// loadJSONSchema reads a JSON Schema file from disk, returning it both as a
+// compiled validator and as a plain map so it can also be sent to the
+// backend as response_format/guided_json.
+func loadJSONSchema(path string) (*jsonschema.Schema, map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading schema file %s: %w", path, err)
+	}
+
+	var schemaMap map[string]any
+	if err := json.Unmarshal(data, &schemaMap); err != nil {
+		return nil, nil, fmt.Errorf("parsing schema file %s: %w", path, err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(path, bytes.NewReader(data)); err != nil {
+		return nil, nil, fmt.Errorf("loading schema file %s: %w", path, err)
+	}
+	schema, err := compiler.Compile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("compiling schema file %s: %w", path, err)
+	}
+
+	return schema, schemaMap, nil
+}
+
+// validateAgainstSchema checks that content is JSON matching schema.
+func validateAgainstSchema(schema *jsonschema.Schema, content string) error {
+	var v any
+	if err := json.Unmarshal([]byte(content), &v); err != nil {
+		return fmt.Errorf("model output is not valid JSON: %w", err)
+	}
+	if err := schema.Validate(v); err != nil {
+		return fmt.Errorf("model output does not match schema: %w", err)
+	}
+	return nil
+}
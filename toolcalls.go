@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/bplaxco/vllmctl/pkg/providers"
+	"github.com/bplaxco/vllmctl/pkg/tools"
+)
+
+// maxToolLoopIterations bounds how many tool-call round trips runToolLoop
+// will make before giving up, so a model that keeps requesting tool calls
+// can't spin forever making live API calls.
+const maxToolLoopIterations = 10
+
+// errNoTTY is returned by confirmToolCall when there's no controlling
+// terminal to prompt on, e.g. because the user's prompt was piped into
+// stdin. Tool execution must not silently proceed (or silently decline) in
+// that case.
+var errNoTTY = errors.New("no controlling terminal available to confirm tool call")
+
+// toolDefs converts a tool registry into the ToolDef list sent on the
+// request so the model knows which tools it may call.
+func toolDefs(registry tools.Registry) []providers.ToolDef {
+	defs := make([]providers.ToolDef, 0, len(registry))
+	for _, tool := range registry {
+		defs = append(defs, providers.ToolDef{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			Parameters:  tool.Parameters(),
+		})
+	}
+	return defs
+}
+
+// confirmToolCall asks the user whether to run a requested tool call, since
+// tools like "shell" can take arbitrary action on the local machine. It
+// prompts on the controlling terminal rather than stdin, since stdin may
+// already be consumed (or piped) by the user's prompt.
+func confirmToolCall(call providers.ToolCall) (bool, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", errNoTTY, err)
+	}
+	defer tty.Close()
+
+	fmt.Fprintf(tty, "Run tool %s(%s)? [y/N] ", call.Name, string(call.Arguments))
+	line, _ := bufio.NewReader(tty).ReadString('\n')
+	return line == "y\n" || line == "Y\n", nil
+}
+
+// runToolLoop sends chatRequest, and for as long as the model responds with
+// tool calls, executes the requested (and user-confirmed) tools from
+// registry and feeds their output back as "tool" messages, until the model
+// returns a final answer. It returns that answer and the full message
+// history, including every tool call and result, so callers can persist it.
+func runToolLoop(ctx context.Context, provider providers.Provider, chatRequest providers.ChatRequest, registry tools.Registry) (providers.Response, []providers.Message, error) {
+	for i := 0; i < maxToolLoopIterations; i++ {
+		resp, err := provider.Chat(ctx, chatRequest)
+		if err != nil {
+			return providers.Response{}, chatRequest.Messages, err
+		}
+
+		if len(resp.ToolCalls) == 0 {
+			return resp, chatRequest.Messages, nil
+		}
+
+		chatRequest.Messages = append(chatRequest.Messages, providers.Message{
+			Role:      "assistant",
+			Content:   resp.Content,
+			ToolCalls: resp.ToolCalls,
+		})
+
+		for _, call := range resp.ToolCalls {
+			result, err := executeToolCall(call, registry)
+			if errors.Is(err, errNoTTY) {
+				return providers.Response{}, chatRequest.Messages, err
+			}
+			if err != nil {
+				result = fmt.Sprintf("error: %v", err)
+			}
+			chatRequest.Messages = append(chatRequest.Messages, providers.Message{
+				Role:       "tool",
+				Content:    result,
+				ToolCallID: call.ID,
+				Name:       call.Name,
+			})
+		}
+	}
+
+	return providers.Response{}, chatRequest.Messages, fmt.Errorf("tool loop exceeded %d round trips without a final answer", maxToolLoopIterations)
+}
+
+func executeToolCall(call providers.ToolCall, registry tools.Registry) (string, error) {
+	tool, ok := registry[call.Name]
+	if !ok {
+		return "", fmt.Errorf("model requested unregistered tool %q (enable it with --tools)", call.Name)
+	}
+
+	confirmed, err := confirmToolCall(call)
+	if err != nil {
+		return "", err
+	}
+	if !confirmed {
+		return "", fmt.Errorf("user declined to run tool %q", call.Name)
+	}
+
+	return tool.Execute(call.Arguments)
+}